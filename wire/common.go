@@ -0,0 +1,417 @@
+// Copyright (c) 2013-2015 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// MaxVarIntPayload is the maximum payload size for a variable length integer.
+const MaxVarIntPayload = 9
+
+// littleEndian is the byte order used to encode and decode integer fields in
+// wire messages.
+var littleEndian = binary.LittleEndian
+
+// readElement reads the next sequence of bytes from r using little endian
+// depending on the concrete type of element.
+func readElement(r io.Reader, element interface{}) error {
+	switch e := element.(type) {
+	case *int32:
+		rv, err := readUint32(r)
+		if err != nil {
+			return err
+		}
+		*e = int32(rv)
+		return nil
+
+	case *uint8:
+		var buf [1]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return err
+		}
+		*e = buf[0]
+		return nil
+
+	case *uint16:
+		var buf [2]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return err
+		}
+		*e = littleEndian.Uint16(buf[:])
+		return nil
+
+	case *uint32:
+		rv, err := readUint32(r)
+		if err != nil {
+			return err
+		}
+		*e = rv
+		return nil
+
+	case *int64:
+		rv, err := readUint64(r)
+		if err != nil {
+			return err
+		}
+		*e = int64(rv)
+		return nil
+
+	case *uint64:
+		rv, err := readUint64(r)
+		if err != nil {
+			return err
+		}
+		*e = rv
+		return nil
+
+	case *bool:
+		var buf [1]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return err
+		}
+		*e = buf[0] != 0x00
+		return nil
+
+	case *[4]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *[16]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *[33]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *[64]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *[1047]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *[1218]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *[MixMsgSize]byte:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *chainhash.Hash:
+		_, err := io.ReadFull(r, e[:])
+		return err
+
+	case *InvType:
+		rv, err := readUint32(r)
+		if err != nil {
+			return err
+		}
+		*e = InvType(rv)
+		return nil
+	}
+
+	// Fall back to the slow path using binary.Read, which is useful for
+	// basic types that don't warrant a specialized case above.
+	return binary.Read(r, littleEndian, element)
+}
+
+// writeElement writes the little endian representation of element to w.
+func writeElement(w io.Writer, element interface{}) error {
+	switch e := element.(type) {
+	case int32:
+		return writeUint32(w, uint32(e))
+
+	case uint8:
+		_, err := w.Write([]byte{e})
+		return err
+
+	case uint16:
+		var buf [2]byte
+		littleEndian.PutUint16(buf[:], e)
+		_, err := w.Write(buf[:])
+		return err
+
+	case uint32:
+		return writeUint32(w, e)
+
+	case int64:
+		return writeUint64(w, uint64(e))
+
+	case uint64:
+		return writeUint64(w, e)
+
+	case bool:
+		if e {
+			_, err := w.Write([]byte{0x01})
+			return err
+		}
+		_, err := w.Write([]byte{0x00})
+		return err
+
+	case [4]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case [16]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case [33]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case [64]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case [1047]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case [1218]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case [MixMsgSize]byte:
+		_, err := w.Write(e[:])
+		return err
+
+	case chainhash.Hash:
+		_, err := w.Write(e[:])
+		return err
+
+	case InvType:
+		return writeUint32(w, uint32(e))
+	}
+
+	return binary.Write(w, littleEndian, element)
+}
+
+// readUint32 reads four bytes from r and returns the result as a uint32
+// using little endian byte order.
+func readUint32(r io.Reader) (uint32, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return littleEndian.Uint32(buf[:]), nil
+}
+
+// writeUint32 writes v to w using little endian byte order.
+func writeUint32(w io.Writer, v uint32) error {
+	var buf [4]byte
+	littleEndian.PutUint32(buf[:], v)
+	_, err := w.Write(buf[:])
+	return err
+}
+
+// readUint64 reads eight bytes from r and returns the result as a uint64
+// using little endian byte order.
+func readUint64(r io.Reader) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return littleEndian.Uint64(buf[:]), nil
+}
+
+// writeUint64 writes v to w using little endian byte order.
+func writeUint64(w io.Writer, v uint64) error {
+	var buf [8]byte
+	littleEndian.PutUint64(buf[:], v)
+	_, err := w.Write(buf[:])
+	return err
+}
+
+// readElements reads multiple items from r. It is equivalent to calling
+// readElement for each item in the slice.
+func readElements(r io.Reader, elements ...interface{}) error {
+	for _, element := range elements {
+		if err := readElement(r, element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeElements writes multiple items to w. It is equivalent to calling
+// writeElement for each item in the slice.
+func writeElements(w io.Writer, elements ...interface{}) error {
+	for _, element := range elements {
+		if err := writeElement(w, element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadVarInt reads a variable length integer from r and returns it as a
+// uint64.
+func ReadVarInt(r io.Reader, pver uint32) (uint64, error) {
+	const op = "ReadVarInt"
+
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:1]); err != nil {
+		return 0, err
+	}
+
+	discriminant := buf[0]
+	switch discriminant {
+	case 0xff:
+		if _, err := io.ReadFull(r, buf[:8]); err != nil {
+			return 0, err
+		}
+		rv := littleEndian.Uint64(buf[:8])
+		if rv < 0x100000000 {
+			return 0, messageErrorf(op, ErrNonCanonicalVarInt,
+				"non-canonically encoded variable length integer")
+		}
+		return rv, nil
+
+	case 0xfe:
+		if _, err := io.ReadFull(r, buf[:4]); err != nil {
+			return 0, err
+		}
+		rv := uint64(littleEndian.Uint32(buf[:4]))
+		if rv < 0x10000 {
+			return 0, messageErrorf(op, ErrNonCanonicalVarInt,
+				"non-canonically encoded variable length integer")
+		}
+		return rv, nil
+
+	case 0xfd:
+		if _, err := io.ReadFull(r, buf[:2]); err != nil {
+			return 0, err
+		}
+		rv := uint64(littleEndian.Uint16(buf[:2]))
+		if rv < 0xfd {
+			return 0, messageErrorf(op, ErrNonCanonicalVarInt,
+				"non-canonically encoded variable length integer")
+		}
+		return rv, nil
+
+	default:
+		return uint64(discriminant), nil
+	}
+}
+
+// WriteVarInt serializes val to w using a variable number of bytes depending
+// on its value.
+func WriteVarInt(w io.Writer, pver uint32, val uint64) error {
+	if val < 0xfd {
+		_, err := w.Write([]byte{uint8(val)})
+		return err
+	}
+
+	if val <= 0xffff {
+		var buf [3]byte
+		buf[0] = 0xfd
+		littleEndian.PutUint16(buf[1:], uint16(val))
+		_, err := w.Write(buf[:])
+		return err
+	}
+
+	if val <= 0xffffffff {
+		var buf [5]byte
+		buf[0] = 0xfe
+		littleEndian.PutUint32(buf[1:], uint32(val))
+		_, err := w.Write(buf[:])
+		return err
+	}
+
+	var buf [9]byte
+	buf[0] = 0xff
+	littleEndian.PutUint64(buf[1:], val)
+	_, err := w.Write(buf[:])
+	return err
+}
+
+// VarIntSerializeSize returns the number of bytes it would take to serialize
+// val as a variable length integer.
+func VarIntSerializeSize(val uint64) int {
+	if val < 0xfd {
+		return 1
+	}
+	if val <= 0xffff {
+		return 3
+	}
+	if val <= 0xffffffff {
+		return 5
+	}
+	return 9
+}
+
+// ReadVarString reads a variable length string from r and returns it as a
+// Go string.
+func ReadVarString(r io.Reader, pver uint32) (string, error) {
+	const op = "ReadVarString"
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return "", err
+	}
+
+	if count > MaxMessagePayload {
+		return "", messageErrorf(op, ErrVarStringTooLong,
+			"variable length string is too long")
+	}
+
+	buf := make([]byte, count)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// WriteVarString serializes str to w as a variable length integer followed
+// by the string itself.
+func WriteVarString(w io.Writer, pver uint32, str string) error {
+	if err := WriteVarInt(w, pver, uint64(len(str))); err != nil {
+		return err
+	}
+	_, err := w.Write([]byte(str))
+	return err
+}
+
+// ReadVarBytes reads a variable length byte slice from r. The fieldName
+// parameter is only used in the error message should the byte slice exceed
+// the maxAllowed parameter.
+func ReadVarBytes(r io.Reader, pver uint32, maxAllowed uint32, fieldName string) ([]byte, error) {
+	const op = "ReadVarBytes"
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > uint64(maxAllowed) {
+		return nil, messageErrorf(op, ErrVarBytesTooLong,
+			"%s is larger than the max allowed size [count %d, max %d]",
+			fieldName, count, maxAllowed)
+	}
+
+	b := make([]byte, count)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WriteVarBytes serializes bytes to w as a variable length integer followed
+// by the bytes themselves.
+func WriteVarBytes(w io.Writer, pver uint32, bytes []byte) error {
+	if err := WriteVarInt(w, pver, uint64(len(bytes))); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes)
+	return err
+}
@@ -13,9 +13,14 @@ type ErrorCode int
 
 // These constants are used to identify a specific Error.
 const (
+	// ErrOther is a sentinel that occupies the zero value so that a
+	// zero-valued ErrorCode (e.g. from an uninitialized MessageError)
+	// is never mistaken for a concrete, coded error.
+	ErrOther ErrorCode = iota
+
 	// ErrVarBytesTooLong is returned when a variable-length byte slice
 	// exceeds the maximum message size allowed.
-	ErrVarBytesTooLong ErrorCode = iota
+	ErrVarBytesTooLong
 
 	// ErrMsgInvalidForPVer is returned when a message is invalid for
 	// the expected protocol version.
@@ -28,29 +33,97 @@ const (
 	// formatting requirements does not conform to the requirements.
 	ErrMalformedStrictString
 
-	// ErrTooManyManyMixPairReqs is returned when the number of mix pair
-	// request message hashes exceeds the maximum allowed.
-	ErrTooManyManyMixPairReqs
+	// ErrTooManyMixPairReqUTXOs is returned when a MixPairReq message
+	// contains more UTXOs than allowed by the protocol.
+	ErrTooManyMixPairReqUTXOs
 
 	// ErrMixPairReqScriptClassTooLong is returned when a mixing script
 	// class type string is longer than allowed by the protocol.
 	ErrMixPairReqScriptClassTooLong
 
-	// ErrTooManyMixPairReqUTXOs is returned when a MixPairReq message
-	// contains more UTXOs than allowed by the protocol.
-	ErrTooManyMixPairReqUTXOs
-
 	// ErrTooManyPrevMixMsgs is returned when too many previous messages of
 	// a mix run are referenced by a message.
 	ErrTooManyPrevMixMsgs
+
+	// ErrTooManyMixCiphertexts is returned when a MsgMixCiphertexts
+	// message contains more ciphertexts than allowed by the protocol.
+	ErrTooManyMixCiphertexts
+
+	// ErrTooManyMixDCNetVectors is returned when a mixing message
+	// contains more XOR DC-net vectors than allowed by the protocol.
+	ErrTooManyMixDCNetVectors
+
+	// ErrWrongNetwork is returned when a message intended for a
+	// different bitcoin network is received.
+	ErrWrongNetwork
+
+	// ErrMalformedCommand is returned when a message command is
+	// malformed (e.g. a NUL byte in its padding is followed by a non-NUL
+	// byte).
+	ErrMalformedCommand
+
+	// ErrUnknownCommand is returned when a message command is not
+	// recognized as a valid message type.
+	ErrUnknownCommand
+
+	// ErrPayloadTooLarge is returned when a message payload exceeds
+	// MaxMessagePayload.
+	ErrPayloadTooLarge
+
+	// ErrChecksumMismatch is returned when a message payload does not
+	// match the checksum in the message header.
+	ErrChecksumMismatch
+
+	// ErrNonCanonicalVarInt is returned when a variable length integer is
+	// not canonically encoded.
+	ErrNonCanonicalVarInt
+
+	// ErrVarStringTooLong is returned when a variable length string
+	// exceeds MaxMessagePayload.
+	ErrVarStringTooLong
+
+	// ErrTooManyAddrs is returned when an addr message contains more
+	// addresses than the protocol allows.
+	ErrTooManyAddrs
+
+	// ErrTooManyInvPerMsg is returned when an inv message contains more
+	// inventory vectors than the protocol allows.
+	ErrTooManyInvPerMsg
+
+	// ErrTooManyHeadersPerMsg is returned when a headers message
+	// contains more block headers than the protocol allows.
+	ErrTooManyHeadersPerMsg
+
+	// ErrTooManyFilterHeaders is returned when a cfheaders message
+	// contains more filter headers than the protocol allows.
+	ErrTooManyFilterHeaders
+
+	// ErrTooManyBlockLocators is returned when a getblocks or getheaders
+	// message contains more block locator hashes than the protocol
+	// allows.
+	ErrTooManyBlockLocators
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
 var errorCodeStrings = map[ErrorCode]string{
-	ErrTooManyManyMixPairReqs:       "ErrTooManyManyMixPairReqs",
+	ErrOther:                        "ErrOther",
 	ErrMixPairReqScriptClassTooLong: "ErrMixPairReqScriptClassTooLong",
 	ErrTooManyMixPairReqUTXOs:       "ErrTooManyMixPairReqUTXOs",
 	ErrTooManyPrevMixMsgs:           "ErrTooManyPrevMixMsgs",
+	ErrTooManyMixCiphertexts:        "ErrTooManyMixCiphertexts",
+	ErrTooManyMixDCNetVectors:       "ErrTooManyMixDCNetVectors",
+	ErrWrongNetwork:                 "ErrWrongNetwork",
+	ErrMalformedCommand:             "ErrMalformedCommand",
+	ErrUnknownCommand:               "ErrUnknownCommand",
+	ErrPayloadTooLarge:              "ErrPayloadTooLarge",
+	ErrChecksumMismatch:             "ErrChecksumMismatch",
+	ErrNonCanonicalVarInt:           "ErrNonCanonicalVarInt",
+	ErrVarStringTooLong:             "ErrVarStringTooLong",
+	ErrTooManyAddrs:                 "ErrTooManyAddrs",
+	ErrTooManyInvPerMsg:             "ErrTooManyInvPerMsg",
+	ErrTooManyHeadersPerMsg:         "ErrTooManyHeadersPerMsg",
+	ErrTooManyFilterHeaders:         "ErrTooManyFilterHeaders",
+	ErrTooManyBlockLocators:         "ErrTooManyBlockLocators",
 }
 
 // String returns the ErrorCode as a human-readable name.
@@ -92,27 +165,36 @@ func (e ErrorCode) Is(target error) bool {
 // differentiate between general io errors such as io.EOF and issues that
 // resulted from malformed messages.
 type MessageError struct {
-	Func        string    // Function name
+	op          string    // Operation that produced the error, e.g. "MsgTx.BtcDecode"
 	ErrorCode   ErrorCode // Describes the kind of error
 	Description string    // Human readable description of the issue
 }
 
+// Op returns the operation that produced the error, e.g. "MsgTx.BtcDecode"
+// or "ReadVarInt". Callers can branch on Op in addition to ErrorCode to
+// avoid fragile substring matching on Description.
+func (e *MessageError) Op() string {
+	return e.op
+}
+
 // Error satisfies the error interface and prints human-readable errors.
 func (e *MessageError) Error() string {
-	if e.Func != "" {
-		return fmt.Sprintf("%v: %v", e.Func, e.Description)
+	if e.op != "" {
+		return fmt.Sprintf("wire: %v: %v", e.op, e.Description)
 	}
-	return e.Description
+	return fmt.Sprintf("wire: %v", e.Description)
 }
 
-// messageError creates an error for the given function and description.
-func messageError(f string, desc string) *MessageError {
-	return &MessageError{Func: f, Description: desc}
+// messageErrorf creates a coded error for the given operation, formatting
+// the description from format and args as with fmt.Sprintf.
+func messageErrorf(op string, c ErrorCode, format string, args ...interface{}) *MessageError {
+	return &MessageError{op: op, ErrorCode: c, Description: fmt.Sprintf(format, args...)}
 }
 
-// messageErrorWithCode creates an Error given a set of arguments.
-func messageErrorWithCode(funcName string, c ErrorCode, desc string) *MessageError {
-	return &MessageError{Func: funcName, ErrorCode: c, Description: desc}
+// messageErrorNoCode creates an uncoded error for the given operation,
+// formatting the description from format and args as with fmt.Sprintf.
+func messageErrorNoCode(op string, format string, args ...interface{}) *MessageError {
+	return &MessageError{op: op, Description: fmt.Sprintf(format, args...)}
 }
 
 // Is implements the interface to work with the standard library's errors.Is.
@@ -0,0 +1,93 @@
+// Copyright (c) 2013-2015 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// InvType represents the allowed types of inventory vectors. See InvVect.
+type InvType uint32
+
+// These constants define the various supported inventory vector types.
+const (
+	InvTypeError                InvType = 0
+	InvTypeTx                   InvType = 1
+	InvTypeBlock                InvType = 2
+	InvTypeFilteredBlock        InvType = 3
+	InvTypeWitnessBlock         InvType = InvTypeBlock | InvTypeWitnessFlag
+	InvTypeWitnessTx            InvType = InvTypeTx | InvTypeWitnessFlag
+	InvTypeFilteredWitnessBlock InvType = InvTypeFilteredBlock | InvTypeWitnessFlag
+
+	// InvTypeMixPairReq and friends identify the CSPP mixing messages in
+	// inventory and getdata exchanges.
+	InvTypeMixPairReq     InvType = 4
+	InvTypeMixKeyExchange InvType = 5
+	InvTypeMixCiphertexts InvType = 6
+	InvTypeMixSlotReserve InvType = 7
+	InvTypeMixDCNet       InvType = 8
+	InvTypeMixConfirm     InvType = 9
+	InvTypeMixSecrets     InvType = 10
+)
+
+// InvTypeWitnessFlag is OR'd into an inventory type to indicate the witness
+// encoding is requested or provided for the inventoried item.
+const InvTypeWitnessFlag InvType = 1 << 30
+
+// Map of inventory types back to their constant names for pretty printing.
+var ivStrings = map[InvType]string{
+	InvTypeError:                "ERROR",
+	InvTypeTx:                   "MSG_TX",
+	InvTypeBlock:                "MSG_BLOCK",
+	InvTypeFilteredBlock:        "MSG_FILTERED_BLOCK",
+	InvTypeWitnessBlock:         "MSG_WITNESS_BLOCK",
+	InvTypeWitnessTx:            "MSG_WITNESS_TX",
+	InvTypeFilteredWitnessBlock: "MSG_FILTERED_WITNESS_BLOCK",
+	InvTypeMixPairReq:           "MSG_MIXPAIRREQ",
+	InvTypeMixKeyExchange:       "MSG_MIXKEYXCHG",
+	InvTypeMixCiphertexts:       "MSG_MIXCIPHERTEXTS",
+	InvTypeMixSlotReserve:       "MSG_MIXSLOTRESERVE",
+	InvTypeMixDCNet:             "MSG_MIXDCNET",
+	InvTypeMixConfirm:           "MSG_MIXCONFIRM",
+	InvTypeMixSecrets:           "MSG_MIXSECRETS",
+}
+
+// String returns the InvType in human-readable form.
+func (invtype InvType) String() string {
+	if s, ok := ivStrings[invtype]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown InvType (%d)", uint32(invtype))
+}
+
+// InvVect defines a bitcoin inventory vector which is used to describe data,
+// as specified by the Type field, that a peer wants, has, or does not have
+// to another peer.
+type InvVect struct {
+	Type InvType        // Type of data
+	Hash chainhash.Hash // Hash of the data
+}
+
+// NewInvVect returns a new InvVect using the provided type and hash.
+func NewInvVect(typ InvType, hash *chainhash.Hash) *InvVect {
+	return &InvVect{
+		Type: typ,
+		Hash: *hash,
+	}
+}
+
+// readInvVect reads an encoded InvVect from r depending on the protocol
+// version.
+func readInvVect(r io.Reader, pver uint32, iv *InvVect) error {
+	return readElements(r, &iv.Type, &iv.Hash)
+}
+
+// writeInvVect serializes an InvVect to w depending on the protocol version.
+func writeInvVect(w io.Writer, pver uint32, iv *InvVect) error {
+	return writeElements(w, iv.Type, iv.Hash)
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2013-2016 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// MessageHeaderSize is the number of bytes in a bitcoin message header.
+// Bitcoin network (magic) 4 bytes + command 12 bytes + payload length 4
+// bytes + checksum 4 bytes.
+const MessageHeaderSize = 24
+
+// CommandSize is the fixed size of all commands in the common bitcoin
+// message header. Shorter commands must be zero padded.
+const CommandSize = 12
+
+// MaxMessagePayload is the maximum bytes a message can be regardless of
+// other individual limits imposed by messages themselves.
+const MaxMessagePayload = (1024 * 1024 * 32) // 32MB
+
+// Commands used in bitcoin message headers which describe the type of
+// message.
+const (
+	CmdVersion    = "version"
+	CmdVerAck     = "verack"
+	CmdGetAddr    = "getaddr"
+	CmdAddr       = "addr"
+	CmdGetBlocks  = "getblocks"
+	CmdInv        = "inv"
+	CmdGetData    = "getdata"
+	CmdNotFound   = "notfound"
+	CmdBlock      = "block"
+	CmdTx         = "tx"
+	CmdGetHeaders = "getheaders"
+	CmdHeaders    = "headers"
+	CmdPing       = "ping"
+	CmdPong       = "pong"
+	CmdReject     = "reject"
+
+	// The following commands make up the CoinShuffle++ (CSPP) mixing
+	// message suite. See MsgMixPairReq and friends for details of each
+	// stage of a mix run.
+	CmdMixPairReq     = "mixpairreq"
+	CmdMixKeyExchange = "mixkeyxchg"
+	CmdMixCiphertexts = "mixciphtxts"
+	CmdMixSlotReserve = "mixslotres"
+	CmdMixDCNet       = "mixdcnet"
+	CmdMixConfirm     = "mixconfirm"
+	CmdMixSecrets     = "mixsecrets"
+)
+
+// BitcoinNet represents which bitcoin network a message belongs to.
+type BitcoinNet uint32
+
+// Constants used to indicate the message bitcoin network.
+const (
+	MainNet BitcoinNet = 0xd9b4bef9
+	TestNet BitcoinNet = 0xdab5bffa
+)
+
+// MessageEncoding represents the wire message encoding format to be used.
+type MessageEncoding uint32
+
+// Encoding types used by messages that have multiple ways of encoding
+// themselves depending on protocol version and other negotiated options.
+const (
+	// BaseEncoding encodes all messages in the original format specified
+	// for the bitcoin wire protocol.
+	BaseEncoding MessageEncoding = 1 << iota
+
+	// WitnessEncoding encodes all messages other than transaction
+	// messages using the original format specified for the bitcoin wire
+	// protocol. Transaction messages are encoded using the new witness
+	// serialization.
+	WitnessEncoding
+)
+
+// Message is an interface that describes a bitcoin message. A type that
+// implements Message has complete control over the representation of its
+// data and may therefore contain additional or fewer fields than those
+// which are used directly in the protocol encoded message.
+type Message interface {
+	BtcDecode(io.Reader, uint32, MessageEncoding) error
+	BtcEncode(io.Writer, uint32, MessageEncoding) error
+	Command() string
+	MaxPayloadLength(uint32) uint32
+}
+
+// makeEmptyMessage creates a message of the appropriate concrete type based
+// on the command.
+func makeEmptyMessage(command string) (Message, error) {
+	var msg Message
+	switch command {
+	case CmdVersion:
+		msg = &MsgVersion{}
+
+	case CmdInv:
+		msg = &MsgInv{}
+
+	case CmdHeaders:
+		msg = &MsgHeaders{}
+
+	case CmdAddr:
+		msg = &MsgAddr{}
+
+	case CmdMixPairReq:
+		msg = &MsgMixPairReq{}
+
+	case CmdMixKeyExchange:
+		msg = &MsgMixKeyExchange{}
+
+	case CmdMixCiphertexts:
+		msg = &MsgMixCiphertexts{}
+
+	case CmdMixSlotReserve:
+		msg = &MsgMixSlotReserve{}
+
+	case CmdMixDCNet:
+		msg = &MsgMixDCNet{}
+
+	case CmdMixConfirm:
+		msg = &MsgMixConfirm{}
+
+	case CmdMixSecrets:
+		msg = &MsgMixSecrets{}
+
+	default:
+		const op = "makeEmptyMessage"
+		return nil, messageErrorf(op, ErrUnknownCommand, "unhandled command [%s]",
+			command)
+	}
+	return msg, nil
+}
+
+// messageHeader defines the header structure for all bitcoin protocol
+// messages.
+type messageHeader struct {
+	magic    BitcoinNet
+	command  string
+	length   uint32
+	checksum [4]byte
+}
+
+// readMessageHeader reads a bitcoin message header from r.
+func readMessageHeader(r io.Reader) (int, *messageHeader, error) {
+	const op = "readMessageHeader"
+
+	var headerBytes [MessageHeaderSize]byte
+	n, err := io.ReadFull(r, headerBytes[:])
+	if err != nil {
+		return n, nil, err
+	}
+	hr := bytes.NewReader(headerBytes[:])
+
+	var command [CommandSize]byte
+	hdr := messageHeader{}
+	if err := readElements(hr, &hdr.magic, &command, &hdr.length,
+		&hdr.checksum); err != nil {
+		return n, nil, err
+	}
+
+	// Shorter commands are padded with NUL bytes; a NUL byte followed by
+	// any non-NUL byte is malformed. A command filling all CommandSize
+	// bytes carries no NUL byte at all.
+	trimmed := bytes.TrimRight(command[:], "\x00")
+	if bytes.IndexByte(trimmed, 0x00) != -1 {
+		return n, nil, messageErrorf(op, ErrMalformedCommand,
+			"invalid command string terminator")
+	}
+	hdr.command = string(trimmed)
+
+	return n, &hdr, nil
+}
+
+// ReadMessageWithEncodingN reads, validates, and parses the next bitcoin
+// message from r for the provided protocol version and specific message
+// encoding. It returns the number of bytes read in addition to the parsed
+// Message and raw bytes which comprise the message.
+func ReadMessageWithEncodingN(r io.Reader, pver uint32, btcnet BitcoinNet,
+	enc MessageEncoding) (int, Message, []byte, error) {
+
+	const op = "ReadMessageWithEncodingN"
+
+	totalBytes := 0
+	n, hdr, err := readMessageHeader(r)
+	totalBytes += n
+	if err != nil {
+		return totalBytes, nil, nil, err
+	}
+
+	if hdr.magic != btcnet {
+		return totalBytes, nil, nil, messageErrorf(op, ErrWrongNetwork,
+			"message from other network [%v]", hdr.magic)
+	}
+
+	if hdr.length > MaxMessagePayload {
+		return totalBytes, nil, nil, messageErrorf(op, ErrPayloadTooLarge,
+			"payload exceeds max message payload size [len %d]", hdr.length)
+	}
+
+	payload := make([]byte, hdr.length)
+	n, err = io.ReadFull(r, payload)
+	totalBytes += n
+	if err != nil {
+		return totalBytes, nil, nil, err
+	}
+
+	checksum := chainhash.DoubleHashB(payload)[:4]
+	if !bytes.Equal(checksum, hdr.checksum[:]) {
+		return totalBytes, nil, nil, messageErrorf(op, ErrChecksumMismatch,
+			"payload checksum failed - header indicates %x, but actual "+
+				"checksum is %x", hdr.checksum, checksum)
+	}
+
+	// makeEmptyMessage already returns a *MessageError carrying its own
+	// op and ErrUnknownCommand; propagate it unchanged.
+	msg, err := makeEmptyMessage(hdr.command)
+	if err != nil {
+		return totalBytes, nil, nil, err
+	}
+
+	if err := msg.BtcDecode(bytes.NewReader(payload), pver, enc); err != nil {
+		return totalBytes, nil, nil, err
+	}
+
+	return totalBytes, msg, payload, nil
+}
+
+// ReadMessageN reads, validates, and parses the next bitcoin message from r
+// using the base message encoding for the provided protocol version.
+func ReadMessageN(r io.Reader, pver uint32, btcnet BitcoinNet) (int, Message, []byte, error) {
+	return ReadMessageWithEncodingN(r, pver, btcnet, BaseEncoding)
+}
+
+// WriteMessageWithEncodingN writes a bitcoin message msg to w using the
+// specified encoding. It returns the number of bytes written.
+func WriteMessageWithEncodingN(w io.Writer, msg Message, pver uint32,
+	btcnet BitcoinNet, enc MessageEncoding) (int, error) {
+
+	const op = "WriteMessageWithEncodingN"
+
+	var payloadBuf bytes.Buffer
+	if err := msg.BtcEncode(&payloadBuf, pver, enc); err != nil {
+		return 0, err
+	}
+	payload := payloadBuf.Bytes()
+
+	if uint32(len(payload)) > msg.MaxPayloadLength(pver) {
+		return 0, messageErrorf(op, ErrPayloadTooLarge,
+			"message payload is too large - encoded %d bytes, but maximum "+
+				"message payload is %d bytes", len(payload), msg.MaxPayloadLength(pver))
+	}
+
+	var command [CommandSize]byte
+	copy(command[:], msg.Command())
+	checksum := chainhash.DoubleHashB(payload)[:4]
+
+	var hw bytes.Buffer
+	if err := writeElements(&hw, btcnet, command, uint32(len(payload))); err != nil {
+		return 0, err
+	}
+	hw.Write(checksum)
+	hw.Write(payload)
+
+	n, err := w.Write(hw.Bytes())
+	return n, err
+}
+
+// WriteMessageN writes a bitcoin message msg to w using the base message
+// encoding for the provided protocol version.
+func WriteMessageN(w io.Writer, msg Message, pver uint32, btcnet BitcoinNet) (int, error) {
+	return WriteMessageWithEncodingN(w, msg, pver, btcnet, BaseEncoding)
+}
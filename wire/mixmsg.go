@@ -0,0 +1,120 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// Sizes, in bytes, of the fixed-length fields shared by every message in
+// the CoinShuffle++ (CSPP) mixing message suite.
+const (
+	// MixMsgSize is the size of the session ID field shared by all mixing
+	// messages.
+	MixMsgSize = 32
+
+	// MixIdentitySize is the size of the compressed secp256k1 mixing
+	// identity carried by every mixing message.
+	MixIdentitySize = 33
+
+	// MixSignatureSize is the size of the Schnorr signature that proves
+	// ownership of the mixing identity over the remainder of the message.
+	MixSignatureSize = 64
+
+	// MaxMixPrevMsgs is the maximum number of previous mix message hashes
+	// that may be referenced by a single mixing message.
+	MaxMixPrevMsgs = 64
+
+	// MaxMixPairReqScriptClassSize is the maximum length, in bytes, of
+	// the script class string advertised by a MsgMixPairReq.
+	MaxMixPairReqScriptClassSize = 32
+
+	// MaxMixPairReqUTXOs is the maximum number of UTXOs a single
+	// MsgMixPairReq may advertise as proof of the funds it is mixing.
+	MaxMixPairReqUTXOs = 64
+)
+
+// mixMsgHeader holds the fields common to every message in the mixing
+// message suite. It is embedded by value (not by reference) in each
+// concrete message type so that BtcEncode/BtcDecode read naturally in the
+// order the fields appear on the wire.
+type mixMsgHeader struct {
+	// Identity is the compressed secp256k1 public key identifying the
+	// sender of the message for the lifetime of the mix run.
+	Identity [MixIdentitySize]byte
+
+	// Signature is a Schnorr signature of the serialized message
+	// (excluding this field) made with the private key for Identity.
+	Signature [MixSignatureSize]byte
+
+	// SessionID is the random session identifier shared by all
+	// participants of this mix run.
+	SessionID [MixMsgSize]byte
+
+	// Expiry is the block height at which the session is considered to
+	// have failed if it has not already completed.
+	Expiry uint32
+
+	// UnmixedPosition is the position of the sender's unmixed output or
+	// input amongst the unmixed pairing of this mix run, and Run is the
+	// number of times the sender has had to repeat this run (due to
+	// blame assignment) for the session.
+	UnmixedPosition uint32
+	Run             uint32
+
+	// PrevMsgs references the hashes of prior mixing messages the
+	// sender has observed for this run.
+	PrevMsgs []chainhash.Hash
+}
+
+// readMixMsgHeader reads the fields shared by every mixing message from r.
+func readMixMsgHeader(r io.Reader, pver uint32, h *mixMsgHeader) error {
+	const op = "readMixMsgHeader"
+
+	err := readElements(r, &h.Identity, &h.Signature, &h.SessionID,
+		&h.Expiry, &h.UnmixedPosition, &h.Run)
+	if err != nil {
+		return err
+	}
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxMixPrevMsgs {
+		return messageErrorf(op, ErrTooManyPrevMixMsgs,
+			"too many previous mix message hashes")
+	}
+
+	h.PrevMsgs = make([]chainhash.Hash, count)
+	for i := range h.PrevMsgs {
+		if err := readElement(r, &h.PrevMsgs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMixMsgHeader serializes the fields shared by every mixing message to
+// w.
+func writeMixMsgHeader(w io.Writer, pver uint32, h *mixMsgHeader) error {
+	err := writeElements(w, h.Identity, h.Signature, h.SessionID,
+		h.Expiry, h.UnmixedPosition, h.Run)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteVarInt(w, pver, uint64(len(h.PrevMsgs))); err != nil {
+		return err
+	}
+	for _, hash := range h.PrevMsgs {
+		if err := writeElement(w, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
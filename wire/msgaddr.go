@@ -0,0 +1,120 @@
+// Copyright (c) 2013-2015 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MaxAddrPerMsg is the maximum number of addresses that can be in a single
+// bitcoin addr message.
+const MaxAddrPerMsg = 1000
+
+// NetAddress defines information about a peer on the network, including its
+// last known address, supported services, and last seen timestamp.
+type NetAddress struct {
+	Timestamp uint32
+	Services  uint64
+	IP        [16]byte
+	Port      uint16
+}
+
+// readNetAddress reads a bitcoin NetAddress from r.
+func readNetAddress(r io.Reader, pver uint32, na *NetAddress) error {
+	return readElements(r, &na.Timestamp, &na.Services, &na.IP, &na.Port)
+}
+
+// writeNetAddress serializes a NetAddress to w.
+func writeNetAddress(w io.Writer, pver uint32, na *NetAddress) error {
+	return writeElements(w, na.Timestamp, na.Services, na.IP, na.Port)
+}
+
+// MsgAddr implements the Message interface and represents a bitcoin addr
+// message. It is used to deliver known active peers to a peer requesting
+// addresses via getaddr.
+type MsgAddr struct {
+	AddrList []*NetAddress
+}
+
+// AddAddress adds a known active peer to the message.
+func (msg *MsgAddr) AddAddress(na *NetAddress) error {
+	const op = "MsgAddr.AddAddress"
+
+	if len(msg.AddrList)+1 > MaxAddrPerMsg {
+		return messageErrorf(op, ErrTooManyAddrs,
+			"too many addresses in message [max %d]", MaxAddrPerMsg)
+	}
+
+	msg.AddrList = append(msg.AddrList, na)
+	return nil
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgAddr) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgAddr.BtcDecode"
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxAddrPerMsg {
+		return messageErrorf(op, ErrTooManyAddrs,
+			"too many addresses for message [%d]", count)
+	}
+
+	addrList := make([]NetAddress, count)
+	msg.AddrList = make([]*NetAddress, 0, count)
+	for i := uint64(0); i < count; i++ {
+		na := &addrList[i]
+		if err := readNetAddress(r, pver, na); err != nil {
+			return err
+		}
+		msg.AddrList = append(msg.AddrList, na)
+	}
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgAddr) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	const op = "MsgAddr.BtcEncode"
+
+	count := len(msg.AddrList)
+	if count > MaxAddrPerMsg {
+		return messageErrorf(op, ErrTooManyAddrs,
+			"too many addresses for message [%d]", count)
+	}
+
+	if err := WriteVarInt(w, pver, uint64(count)); err != nil {
+		return err
+	}
+	for _, na := range msg.AddrList {
+		if err := writeNetAddress(w, pver, na); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgAddr) Command() string {
+	return CmdAddr
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgAddr) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgAddr returns a new bitcoin addr message that conforms to the
+// Message interface.
+func NewMsgAddr() *MsgAddr {
+	return &MsgAddr{
+		AddrList: make([]*NetAddress, 0, MaxAddrPerMsg),
+	}
+}
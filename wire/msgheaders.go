@@ -0,0 +1,143 @@
+// Copyright (c) 2013-2015 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// MaxBlockHeadersPerMsg is the maximum number of block headers that can be
+// in a single bitcoin headers message.
+const MaxBlockHeadersPerMsg = 2000
+
+// BlockHeader defines information about a block and is used in the bitcoin
+// block (MsgBlock) and headers (MsgHeaders) messages.
+type BlockHeader struct {
+	Version    int32
+	PrevBlock  chainhash.Hash
+	MerkleRoot chainhash.Hash
+	Timestamp  uint32
+	Bits       uint32
+	Nonce      uint32
+}
+
+// readBlockHeader reads a bitcoin block header from r.
+func readBlockHeader(r io.Reader, pver uint32, bh *BlockHeader) error {
+	return readElements(r, &bh.Version, &bh.PrevBlock, &bh.MerkleRoot,
+		&bh.Timestamp, &bh.Bits, &bh.Nonce)
+}
+
+// writeBlockHeader serializes a bitcoin block header to w.
+func writeBlockHeader(w io.Writer, pver uint32, bh *BlockHeader) error {
+	return writeElements(w, bh.Version, bh.PrevBlock, bh.MerkleRoot,
+		bh.Timestamp, bh.Bits, bh.Nonce)
+}
+
+// MsgHeaders implements the Message interface and represents a bitcoin
+// headers message. It is used to deliver block header information in
+// response to a getheaders message.
+type MsgHeaders struct {
+	Headers []*BlockHeader
+}
+
+// AddBlockHeader adds a new block header to the message.
+func (msg *MsgHeaders) AddBlockHeader(bh *BlockHeader) error {
+	const op = "MsgHeaders.AddBlockHeader"
+
+	if len(msg.Headers)+1 > MaxBlockHeadersPerMsg {
+		return messageErrorf(op, ErrTooManyHeadersPerMsg,
+			"too many block headers in message [max %d]", MaxBlockHeadersPerMsg)
+	}
+
+	msg.Headers = append(msg.Headers, bh)
+	return nil
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgHeaders) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgHeaders.BtcDecode"
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxBlockHeadersPerMsg {
+		return messageErrorf(op, ErrTooManyHeadersPerMsg,
+			"too many block headers in message [%d]", count)
+	}
+
+	headers := make([]BlockHeader, count)
+	msg.Headers = make([]*BlockHeader, 0, count)
+	for i := uint64(0); i < count; i++ {
+		bh := &headers[i]
+		if err := readBlockHeader(r, pver, bh); err != nil {
+			return err
+		}
+
+		// A transaction count immediately follows each header in the
+		// wire encoding. It is always zero for headers messages.
+		txCount, err := ReadVarInt(r, pver)
+		if err != nil {
+			return err
+		}
+		if txCount != 0 {
+			return messageErrorNoCode(op,
+				"block header transaction count must be zero, got %d", txCount)
+		}
+
+		msg.Headers = append(msg.Headers, bh)
+	}
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgHeaders) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	const op = "MsgHeaders.BtcEncode"
+
+	count := len(msg.Headers)
+	if count > MaxBlockHeadersPerMsg {
+		return messageErrorf(op, ErrTooManyHeadersPerMsg,
+			"too many block headers in message [%d]", count)
+	}
+
+	if err := WriteVarInt(w, pver, uint64(count)); err != nil {
+		return err
+	}
+	for _, bh := range msg.Headers {
+		if err := writeBlockHeader(w, pver, bh); err != nil {
+			return err
+		}
+		if err := WriteVarInt(w, pver, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgHeaders) Command() string {
+	return CmdHeaders
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgHeaders) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgHeaders returns a new bitcoin headers message that conforms to the
+// Message interface.
+func NewMsgHeaders() *MsgHeaders {
+	return &MsgHeaders{
+		Headers: make([]*BlockHeader, 0, MaxBlockHeadersPerMsg),
+	}
+}
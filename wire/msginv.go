@@ -0,0 +1,105 @@
+// Copyright (c) 2013-2015 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MaxInvPerMsg is the maximum number of inventory vectors that can be in a
+// single bitcoin inv message.
+const MaxInvPerMsg = 50000
+
+// MsgInv implements the Message interface and represents a bitcoin inv
+// message. It is used to advertise data the sender has, or in response to
+// getblocks, to advertise the data the sender wishes to request.
+type MsgInv struct {
+	InvList []*InvVect
+}
+
+// AddInvVect adds an inventory vector to the message.
+func (msg *MsgInv) AddInvVect(iv *InvVect) error {
+	const op = "MsgInv.AddInvVect"
+
+	if len(msg.InvList)+1 > MaxInvPerMsg {
+		return messageErrorf(op, ErrTooManyInvPerMsg,
+			"too many invvect in message [max %d]", MaxInvPerMsg)
+	}
+
+	msg.InvList = append(msg.InvList, iv)
+	return nil
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgInv) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgInv.BtcDecode"
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxInvPerMsg {
+		return messageErrorf(op, ErrTooManyInvPerMsg,
+			"too many invvect in message [%d]", count)
+	}
+
+	invList := make([]InvVect, count)
+	msg.InvList = make([]*InvVect, 0, count)
+	for i := uint64(0); i < count; i++ {
+		iv := &invList[i]
+		if err := readInvVect(r, pver, iv); err != nil {
+			return err
+		}
+		msg.InvList = append(msg.InvList, iv)
+	}
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgInv) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	const op = "MsgInv.BtcEncode"
+
+	count := len(msg.InvList)
+	if count > MaxInvPerMsg {
+		return messageErrorf(op, ErrTooManyInvPerMsg,
+			"too many invvect in message [%d]", count)
+	}
+
+	if err := WriteVarInt(w, pver, uint64(count)); err != nil {
+		return err
+	}
+	for _, iv := range msg.InvList {
+		if err := writeInvVect(w, pver, iv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgInv) Command() string {
+	return CmdInv
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgInv) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgInv returns a new bitcoin inv message that conforms to the Message
+// interface.
+func NewMsgInv() *MsgInv {
+	return &MsgInv{
+		InvList: make([]*InvVect, 0, defaultInvListAlloc),
+	}
+}
+
+// defaultInvListAlloc is the default size used when preallocating the
+// backing array for a new inv message's InvList.
+const defaultInvListAlloc = 32
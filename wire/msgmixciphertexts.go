@@ -0,0 +1,88 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MixCiphertextSize is the size of a single sntrup4591651 ciphertext
+// exchanged in a MsgMixCiphertexts.
+const MixCiphertextSize = 1047
+
+// MaxMixCiphertexts is the maximum number of ciphertexts a single
+// MsgMixCiphertexts may carry, bounded by the maximum number of peers that
+// may participate in a single mix run.
+const MaxMixCiphertexts = 64
+
+// MsgMixCiphertexts implements the Message interface and represents the
+// third stage of a CoinShuffle++ (CSPP) mix run, where each peer publishes
+// the sntrup4591651 ciphertexts encrypted to every other peer's key
+// exchange public key.
+type MsgMixCiphertexts struct {
+	mixMsgHeader
+
+	// Ciphertexts holds one ciphertext addressed to each other peer in
+	// the run, ordered the same as the peers' unmixed positions.
+	Ciphertexts [][MixCiphertextSize]byte
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMixCiphertexts) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgMixCiphertexts.BtcDecode"
+
+	if err := readMixMsgHeader(r, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxMixCiphertexts {
+		return messageErrorf(op, ErrTooManyMixCiphertexts,
+			"too many ciphertexts in mix ciphertexts message")
+	}
+
+	ciphertexts := make([][MixCiphertextSize]byte, count)
+	for i := range ciphertexts {
+		if err := readElement(r, &ciphertexts[i]); err != nil {
+			return err
+		}
+	}
+	msg.Ciphertexts = ciphertexts
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMixCiphertexts) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if err := writeMixMsgHeader(w, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	if err := WriteVarInt(w, pver, uint64(len(msg.Ciphertexts))); err != nil {
+		return err
+	}
+	for _, ciphertext := range msg.Ciphertexts {
+		if err := writeElement(w, ciphertext); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgMixCiphertexts) Command() string {
+	return CmdMixCiphertexts
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgMixCiphertexts) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
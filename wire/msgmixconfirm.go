@@ -0,0 +1,56 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MsgMixConfirm implements the Message interface and represents the final
+// stage of a successful CoinShuffle++ (CSPP) mix run, where a peer
+// publishes the fully-signed mixed transaction for the other peers to
+// broadcast if they have not already observed it.
+type MsgMixConfirm struct {
+	mixMsgHeader
+
+	// Tx is the serialized, fully-signed mixed transaction resulting
+	// from this run.
+	Tx []byte
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMixConfirm) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	if err := readMixMsgHeader(r, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	tx, err := ReadVarBytes(r, pver, MaxMessagePayload, "Tx")
+	if err != nil {
+		return err
+	}
+	msg.Tx = tx
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMixConfirm) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if err := writeMixMsgHeader(w, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+	return WriteVarBytes(w, pver, msg.Tx)
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgMixConfirm) Command() string {
+	return CmdMixConfirm
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgMixConfirm) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MaxMixDCNetVectors is the maximum number of XOR DC-net vectors a single
+// MsgMixDCNet may carry.
+const MaxMixDCNetVectors = 64
+
+// MsgMixDCNet implements the Message interface and represents the exchange
+// output stage of a CoinShuffle++ (CSPP) mix run, where each peer publishes
+// an XOR DC-net vector revealing, in aggregate, every peer's mixed output
+// at its reserved slot.
+type MsgMixDCNet struct {
+	mixMsgHeader
+
+	// DCNet holds this peer's contribution to the mixed output XOR
+	// DC-net, one vector per reserved slot.
+	DCNet [][]byte
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMixDCNet) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgMixDCNet.BtcDecode"
+
+	if err := readMixMsgHeader(r, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxMixDCNetVectors {
+		return messageErrorf(op, ErrTooManyMixDCNetVectors,
+			"too many DC-net vectors in mix DC-net message")
+	}
+
+	dcNet := make([][]byte, count)
+	for i := range dcNet {
+		dcNet[i], err = ReadVarBytes(r, pver, MaxMessagePayload, "DCNet")
+		if err != nil {
+			return err
+		}
+	}
+	msg.DCNet = dcNet
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMixDCNet) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if err := writeMixMsgHeader(w, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	if err := WriteVarInt(w, pver, uint64(len(msg.DCNet))); err != nil {
+		return err
+	}
+	for _, vec := range msg.DCNet {
+		if err := WriteVarBytes(w, pver, vec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgMixDCNet) Command() string {
+	return CmdMixDCNet
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgMixDCNet) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MixKXSize is the size of a serialized sntrup4591651 public key as
+// exchanged in a MsgMixKeyExchange.
+const MixKXSize = 1218
+
+// MsgMixKeyExchange implements the Message interface and represents the
+// second stage of a CoinShuffle++ (CSPP) mix run, where each peer publishes
+// the public keys that will be used to derive the shared keys for the
+// ciphertext and DC-net stages.
+type MsgMixKeyExchange struct {
+	mixMsgHeader
+
+	// ECDH is the secp256k1 public key used to derive a shared secret
+	// with each other peer in the run.
+	ECDH [MixIdentitySize]byte
+
+	// KX is the sntrup4591651 public key used for the post-quantum key
+	// exchange that seeds the ciphertext and DC-net stages.
+	KX [MixKXSize]byte
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMixKeyExchange) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	if err := readMixMsgHeader(r, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+	return readElements(r, &msg.ECDH, &msg.KX)
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMixKeyExchange) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if err := writeMixMsgHeader(w, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+	return writeElements(w, msg.ECDH, msg.KX)
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgMixKeyExchange) Command() string {
+	return CmdMixKeyExchange
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgMixKeyExchange) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgMixKeyExchange returns a new CSPP mix key exchange message that
+// conforms to the Message interface using the passed parameters.
+func NewMsgMixKeyExchange(identity [MixIdentitySize]byte, sessionID [MixMsgSize]byte,
+	expiry, unmixedPosition, run uint32, ecdh [MixIdentitySize]byte,
+	kx [MixKXSize]byte) *MsgMixKeyExchange {
+
+	return &MsgMixKeyExchange{
+		mixMsgHeader: mixMsgHeader{
+			Identity:        identity,
+			SessionID:       sessionID,
+			Expiry:          expiry,
+			UnmixedPosition: unmixedPosition,
+			Run:             run,
+		},
+		ECDH: ecdh,
+		KX:   kx,
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// MixPairReqUTXO describes a single unmixed UTXO a peer is offering as
+// input to a mix run, along with a signature proving the peer controls it.
+type MixPairReqUTXO struct {
+	Hash      chainhash.Hash
+	Index     uint32
+	Signature []byte
+	PubKey    []byte
+}
+
+// MsgMixPairReq implements the Message interface and represents the first
+// message exchanged in a CoinShuffle++ (CSPP) mix run. It advertises the
+// amount and output script class a peer wishes to mix, along with the
+// unmixed UTXOs proving the peer controls the funds being offered.
+type MsgMixPairReq struct {
+	mixMsgHeader
+
+	// Amount is the amount, in atoms, each participant is mixing.
+	Amount int64
+
+	// ScriptClass identifies the output script class the mixed outputs
+	// must be paid to, e.g. "p2pkh-v0".
+	ScriptClass string
+
+	// TxVersion, LockTime, and MessageCount describe the unsigned
+	// transaction the pair request is proposing be constructed from this
+	// mix run.
+	TxVersion    uint16
+	LockTime     uint32
+	MessageCount uint32
+
+	// InputValue is the total value, in atoms, of the UTXOs below.
+	InputValue int64
+
+	// UTXOs are the unmixed UTXOs this peer is contributing to the run.
+	UTXOs []MixPairReqUTXO
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMixPairReq) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgMixPairReq.BtcDecode"
+
+	if err := readMixMsgHeader(r, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	scriptClass, err := ReadVarString(r, pver)
+	if err != nil {
+		return err
+	}
+	if len(scriptClass) > MaxMixPairReqScriptClassSize {
+		return messageErrorf(op, ErrMixPairReqScriptClassTooLong,
+			"mix pair request script class is too long")
+	}
+	msg.ScriptClass = scriptClass
+
+	err = readElements(r, &msg.Amount, &msg.TxVersion, &msg.LockTime,
+		&msg.MessageCount, &msg.InputValue)
+	if err != nil {
+		return err
+	}
+
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if count > MaxMixPairReqUTXOs {
+		return messageErrorf(op, ErrTooManyMixPairReqUTXOs,
+			"too many UTXOs in mix pair request")
+	}
+
+	utxos := make([]MixPairReqUTXO, count)
+	for i := range utxos {
+		utxo := &utxos[i]
+		if err := readElements(r, &utxo.Hash, &utxo.Index); err != nil {
+			return err
+		}
+		utxo.Signature, err = ReadVarBytes(r, pver, MixSignatureSize*2, "Signature")
+		if err != nil {
+			return err
+		}
+		utxo.PubKey, err = ReadVarBytes(r, pver, MixIdentitySize, "PubKey")
+		if err != nil {
+			return err
+		}
+	}
+	msg.UTXOs = utxos
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMixPairReq) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if err := writeMixMsgHeader(w, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	if err := WriteVarString(w, pver, msg.ScriptClass); err != nil {
+		return err
+	}
+
+	err := writeElements(w, msg.Amount, msg.TxVersion, msg.LockTime,
+		msg.MessageCount, msg.InputValue)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteVarInt(w, pver, uint64(len(msg.UTXOs))); err != nil {
+		return err
+	}
+	for _, utxo := range msg.UTXOs {
+		if err := writeElements(w, utxo.Hash, utxo.Index); err != nil {
+			return err
+		}
+		if err := WriteVarBytes(w, pver, utxo.Signature); err != nil {
+			return err
+		}
+		if err := WriteVarBytes(w, pver, utxo.PubKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgMixPairReq) Command() string {
+	return CmdMixPairReq
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgMixPairReq) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgMixPairReq returns a new CSPP mix pair request message that conforms
+// to the Message interface using the passed parameters and defaults for the
+// remaining fields.
+func NewMsgMixPairReq(identity [MixIdentitySize]byte, sessionID [MixMsgSize]byte,
+	expiry, unmixedPosition, run uint32, amount int64, scriptClass string,
+	txVersion uint16, lockTime uint32, messageCount uint32, inputValue int64,
+	utxos []MixPairReqUTXO) *MsgMixPairReq {
+
+	return &MsgMixPairReq{
+		mixMsgHeader: mixMsgHeader{
+			Identity:        identity,
+			SessionID:       sessionID,
+			Expiry:          expiry,
+			UnmixedPosition: unmixedPosition,
+			Run:             run,
+		},
+		Amount:       amount,
+		ScriptClass:  scriptClass,
+		TxVersion:    txVersion,
+		LockTime:     lockTime,
+		MessageCount: messageCount,
+		InputValue:   inputValue,
+		UTXOs:        utxos,
+	}
+}
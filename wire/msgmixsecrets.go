@@ -0,0 +1,119 @@
+// Copyright (c) 2024 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// MixSecrets reveals the secrets a peer used in a failed mix run so the
+// other peers may identify whichever peer misbehaved.
+type MixSecrets struct {
+	// Seed is the random seed the peer used to derive its per-run
+	// randomness.
+	Seed [32]byte
+
+	// ECDHPrivate is the private half of the key exchange ECDH keypair
+	// the peer published in its MsgMixKeyExchange.
+	ECDHPrivate [32]byte
+
+	// KXPrivate is the private half of the sntrup4591651 keypair the
+	// peer published in its MsgMixKeyExchange.
+	KXPrivate [MixKXSize]byte
+
+	// SlotReserveDCNet is the peer's unblinded slot reservation DC-net
+	// vector contribution.
+	SlotReserveDCNet [][]byte
+
+	// DCNet is the peer's unblinded mixed output DC-net vector
+	// contribution.
+	DCNet [][]byte
+}
+
+// MsgMixSecrets implements the Message interface and represents the blame
+// assignment stage of a CoinShuffle++ (CSPP) mix run. A peer publishes this
+// message to reveal its secrets after a run fails, allowing the other
+// peers to identify the misbehaving participant.
+type MsgMixSecrets struct {
+	mixMsgHeader
+
+	// Secrets are the revealed secrets for the failed run.
+	Secrets MixSecrets
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMixSecrets) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	const op = "MsgMixSecrets.BtcDecode"
+
+	if err := readMixMsgHeader(r, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	s := &msg.Secrets
+	err := readElements(r, &s.Seed, &s.ECDHPrivate, &s.KXPrivate)
+	if err != nil {
+		return err
+	}
+
+	for _, dest := range []*[][]byte{&s.SlotReserveDCNet, &s.DCNet} {
+		count, err := ReadVarInt(r, pver)
+		if err != nil {
+			return err
+		}
+		if count > MaxMixDCNetVectors {
+			return messageErrorf(op, ErrTooManyMixDCNetVectors,
+				"too many DC-net vectors in mix secrets message")
+		}
+
+		vecs := make([][]byte, count)
+		for i := range vecs {
+			vecs[i], err = ReadVarBytes(r, pver, MaxMessagePayload, "DCNet")
+			if err != nil {
+				return err
+			}
+		}
+		*dest = vecs
+	}
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMixSecrets) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if err := writeMixMsgHeader(w, pver, &msg.mixMsgHeader); err != nil {
+		return err
+	}
+
+	s := &msg.Secrets
+	err := writeElements(w, s.Seed, s.ECDHPrivate, s.KXPrivate)
+	if err != nil {
+		return err
+	}
+
+	for _, vecs := range [][][]byte{s.SlotReserveDCNet, s.DCNet} {
+		if err := WriteVarInt(w, pver, uint64(len(vecs))); err != nil {
+			return err
+		}
+		for _, vec := range vecs {
+			if err := WriteVarBytes(w, pver, vec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgMixSecrets) Command() string {
+	return CmdMixSecrets
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgMixSecrets) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
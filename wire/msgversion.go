@@ -0,0 +1,96 @@
+// Copyright (c) 2013-2015 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import "io"
+
+// ProtocolVersion is the latest protocol version this package supports.
+const ProtocolVersion uint32 = 70016
+
+// MsgVersion implements the Message interface and represents a bitcoin
+// version message. It is exchanged when a connection is first established
+// and is used to negotiate the protocol version and capabilities of each
+// peer.
+type MsgVersion struct {
+	// ProtocolVersion is the version of the protocol the sender is using.
+	ProtocolVersion int32
+
+	// Services represents the services supported by the sender.
+	Services uint64
+
+	// Timestamp is the time the message was generated, in Unix time.
+	Timestamp int64
+
+	// Nonce is a random identifier used to detect connections to self.
+	Nonce uint64
+
+	// UserAgent identifies the software and version of the sender.
+	UserAgent string
+
+	// LastBlock is the last block the sender has processed.
+	LastBlock int32
+
+	// DisableRelayTx indicates whether the remote peer should announce
+	// relayed transactions or not.
+	DisableRelayTx bool
+}
+
+// BtcDecode decodes r using the wire protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgVersion) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &msg.ProtocolVersion, &msg.Services, &msg.Timestamp,
+		&msg.Nonce)
+	if err != nil {
+		return err
+	}
+
+	userAgent, err := ReadVarString(r, pver)
+	if err != nil {
+		return err
+	}
+	msg.UserAgent = userAgent
+
+	return readElements(r, &msg.LastBlock, &msg.DisableRelayTx)
+}
+
+// BtcEncode encodes the receiver to w using the wire protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgVersion) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, msg.ProtocolVersion, msg.Services, msg.Timestamp,
+		msg.Nonce)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteVarString(w, pver, msg.UserAgent); err != nil {
+		return err
+	}
+
+	return writeElements(w, msg.LastBlock, msg.DisableRelayTx)
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgVersion) Command() string {
+	return CmdVersion
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver. This is part of the Message interface implementation.
+func (msg *MsgVersion) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgVersion returns a new bitcoin version message that conforms to the
+// Message interface using the passed parameters and defaults for the
+// remaining fields.
+func NewMsgVersion(nonce uint64, lastBlock int32, userAgent string) *MsgVersion {
+	return &MsgVersion{
+		ProtocolVersion: int32(ProtocolVersion),
+		Nonce:           nonce,
+		UserAgent:       userAgent,
+		LastBlock:       lastBlock,
+	}
+}